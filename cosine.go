@@ -0,0 +1,100 @@
+package kmeans
+
+import "math"
+
+// CosineSimilarity measures the cosine of the angle between two vectors,
+// in [-1, 1]. It is undefined (ErrInvalidParameter) when either vector has
+// zero magnitude.
+func CosineSimilarity(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	var dot, normFirst, normSecond float64
+	for ii := range firstVector {
+		dot += firstVector[ii] * secondVector[ii]
+		normFirst += firstVector[ii] * firstVector[ii]
+		normSecond += secondVector[ii] * secondVector[ii]
+	}
+	if normFirst == 0 || normSecond == 0 {
+		return 0, ErrInvalidParameter
+	}
+	return dot / (math.Sqrt(normFirst) * math.Sqrt(normSecond)), nil
+}
+
+// CosineDistance is 1 - CosineSimilarity. It is not a true metric since it
+// does not obey the triangle inequality.
+func CosineDistance(firstVector, secondVector []float64) (float64, error) {
+	similarity, err := CosineSimilarity(firstVector, secondVector)
+	if err != nil {
+		return 0, err
+	}
+	return 1 - similarity, nil
+}
+
+// AngularDistance is acos(CosineSimilarity) / pi, normalized to [0, 1].
+// Unlike CosineDistance it obeys the triangle inequality and is a true
+// metric.
+func AngularDistance(firstVector, secondVector []float64) (float64, error) {
+	similarity, err := CosineSimilarity(firstVector, secondVector)
+	if err != nil {
+		return 0, err
+	}
+	// clamp for float rounding before acos, which is undefined outside [-1, 1]
+	if similarity > 1 {
+		similarity = 1
+	} else if similarity < -1 {
+		similarity = -1
+	}
+	return math.Acos(similarity) / math.Pi, nil
+}
+
+// CorrelationDistance is 1 minus the Pearson correlation coefficient of the
+// two vectors, equivalently the cosine distance of the mean-centered
+// vectors.
+func CorrelationDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	meanFirst := mean(firstVector)
+	meanSecond := mean(secondVector)
+
+	centeredFirst := make([]float64, len(firstVector))
+	centeredSecond := make([]float64, len(secondVector))
+	for ii := range firstVector {
+		centeredFirst[ii] = firstVector[ii] - meanFirst
+		centeredSecond[ii] = secondVector[ii] - meanSecond
+	}
+	return CosineDistance(centeredFirst, centeredSecond)
+}
+
+func mean(vector []float64) float64 {
+	sum := 0.
+	for _, v := range vector {
+		sum += v
+	}
+	return sum / float64(len(vector))
+}
+
+// NormalizeL2 scales vector to unit L2 norm in place and returns it. It is
+// intended for re-normalizing cosine-metric centroids after averaging, so
+// that CosineDistance/CosineSimilarity stay meaningful across iterations.
+// There is no k-means driver/centroid-update loop in this package yet for
+// it to be wired into; callers doing their own clustering can call it
+// directly on each updated centroid.
+func NormalizeL2(vector []float64) ([]float64, error) {
+	if len(vector) == 0 {
+		return nil, ErrEmptyInput
+	}
+	norm := 0.
+	for _, v := range vector {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	if norm == 0 {
+		return nil, ErrInvalidParameter
+	}
+	for ii := range vector {
+		vector[ii] /= norm
+	}
+	return vector, nil
+}
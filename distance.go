@@ -17,10 +17,18 @@ implemented separately.
 
 import (
 	"math"
+
+	"wisblue/kmeans/vek"
 )
 
 // Lp Norm of an array, given p >= 1
 func LPNorm(vector []float64, p float64) (float64, error) {
+	if len(vector) == 0 {
+		return 0, ErrEmptyInput
+	}
+	if p < 1 {
+		return 0, ErrInvalidParameter
+	}
 	distance := 0.
 	for _, jj := range vector {
 		distance += math.Pow(math.Abs(jj), p)
@@ -30,32 +38,38 @@ func LPNorm(vector []float64, p float64) (float64, error) {
 
 // 1-norm distance (l_1 distance)
 func ManhattanDistance(firstVector, secondVector []float64) (float64, error) {
-	distance := 0.
-	for ii := range firstVector {
-		distance += math.Abs(firstVector[ii] - secondVector[ii])
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
 	}
-	return distance, nil
+	return vek.Manhattan64(firstVector, secondVector), nil
 }
 
 // 2-norm distance (l_2 distance)
 func EuclideanDistance(firstVector, secondVector []float64) (float64, error) {
 	distance, err := SquaredEuclideanDistance(firstVector, secondVector)
-	return math.Sqrt(distance), err
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(distance), nil
 }
 
 // Higher weight for the points that are far apart
 // Not a real metric as it does not obey triangle inequality
 func SquaredEuclideanDistance(firstVector, secondVector []float64) (float64, error) {
-	distance := 0.
-	for ii := range firstVector {
-		d := firstVector[ii] - secondVector[ii]
-		distance += d * d
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
 	}
-	return distance, nil
+	return vek.SquaredEuclidean64(firstVector, secondVector), nil
 }
 
 // p-norm distance (l_p distance)
 func MinkowskiDistance(firstVector, secondVector []float64, p float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if p < 1 {
+		return 0, ErrInvalidParameter
+	}
 	distance := 0.
 	for ii := range firstVector {
 		distance += math.Pow(math.Abs(firstVector[ii]-secondVector[ii]), p)
@@ -65,6 +79,20 @@ func MinkowskiDistance(firstVector, secondVector []float64, p float64) (float64,
 
 // p-norm distance with weights (weighted l_p distance)
 func WeightedMinkowskiDistance(firstVector, secondVector, weightVector []float64, p float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if p < 1 {
+		return 0, ErrInvalidParameter
+	}
+	if len(weightVector) != len(firstVector) {
+		return 0, ErrSizeMismatch
+	}
+	for _, w := range weightVector {
+		if w < 0 {
+			return 0, ErrInvalidParameter
+		}
+	}
 	distance := 0.
 	for ii := range firstVector {
 		distance += weightVector[ii] * math.Pow(math.Abs(firstVector[ii]-secondVector[ii]), p)
@@ -74,16 +102,16 @@ func WeightedMinkowskiDistance(firstVector, secondVector, weightVector []float64
 
 // infinity norm distance (l_inf distance)
 func ChebyshevDistance(firstVector, secondVector []float64) (float64, error) {
-	distance := 0.
-	for ii := range firstVector {
-		if math.Abs(firstVector[ii]-secondVector[ii]) >= distance {
-			distance = math.Abs(firstVector[ii] - secondVector[ii])
-		}
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
 	}
-	return distance, nil
+	return vek.Chebyshev64(firstVector, secondVector), nil
 }
 
 func HammingDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
 	distance := 0.
 	for ii := range firstVector {
 		if firstVector[ii] != secondVector[ii] {
@@ -94,6 +122,9 @@ func HammingDistance(firstVector, secondVector []float64) (float64, error) {
 }
 
 func BrayCurtisDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
 	numerator, denominator := 0., 0.
 	for ii := range firstVector {
 		numerator += math.Abs(firstVector[ii] - secondVector[ii])
@@ -103,6 +134,9 @@ func BrayCurtisDistance(firstVector, secondVector []float64) (float64, error) {
 }
 
 func CanberraDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
 	distance := 0.
 	for ii := range firstVector {
 		distance += (math.Abs(firstVector[ii]-secondVector[ii]) / (math.Abs(firstVector[ii]) + math.Abs(secondVector[ii])))
@@ -113,11 +147,28 @@ func CanberraDistance(firstVector, secondVector []float64) (float64, error) {
 // given longitude and latitude of two points, calculate the distance between
 // the two points by  great-circle distance method.
 // ref http://www.movable-type.co.uk/scripts/latlong.html
+//
+// firstVector and secondVector must each have length 2, ordered as
+// [longitude, latitude], with longitude in [-180, 180] and latitude in
+// [-90, 90].
 func EarthDistance(firstVector, secondVector []float64) (float64, error) {
-	var R float64 = 6378137 // radius of the earth in meter
-	toRadians := func(d float64) float64 {
-		return d * math.Pi / 180.0
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
 	}
+	if len(firstVector) != 2 {
+		return 0, ErrInvalidParameter
+	}
+	if err := validateLngLat(firstVector); err != nil {
+		return 0, err
+	}
+	if err := validateLngLat(secondVector); err != nil {
+		return 0, err
+	}
+
+	// Kept at its original equatorial radius rather than earthRadiusMeters
+	// (the mean radius HaversineDistance/VincentyDistance use) so this
+	// pre-existing function's output doesn't change for existing callers.
+	var R float64 = 6378137
 
 	lat1 := toRadians(firstVector[1])
 	lat2 := toRadians(secondVector[1])
@@ -127,3 +178,13 @@ func EarthDistance(firstVector, secondVector []float64) (float64, error) {
 
 	return c, nil
 }
+
+// validateLngLat checks that point, ordered as [longitude, latitude], falls
+// within valid geographic ranges.
+func validateLngLat(point []float64) error {
+	lng, lat := point[0], point[1]
+	if lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+		return ErrInvalidParameter
+	}
+	return nil
+}
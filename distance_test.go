@@ -0,0 +1,163 @@
+package kmeans
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomVectors(n int, seed int64) (a, b []float64) {
+	rng := rand.New(rand.NewSource(seed))
+	a = make([]float64, n)
+	b = make([]float64, n)
+	for i := range a {
+		a[i] = rng.Float64()*20 - 10
+		b[i] = rng.Float64()*20 - 10
+	}
+	return a, b
+}
+
+func toFloat32Vector(v []float64) []float32 {
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(x)
+	}
+	return out
+}
+
+// TestValidateVectorsSentinelErrors checks the shared validator every
+// float64 distance function in this package routes through.
+func TestValidateVectorsSentinelErrors(t *testing.T) {
+	cases := []struct {
+		name    string
+		a, b    []float64
+		wantErr error
+	}{
+		{"empty a", nil, []float64{1}, ErrEmptyInput},
+		{"empty b", []float64{1}, nil, ErrEmptyInput},
+		{"mismatched length", []float64{1, 2}, []float64{1}, ErrSizeMismatch},
+		{"ok", []float64{1, 2}, []float64{3, 4}, nil},
+	}
+	for _, c := range cases {
+		if err := validateVectors(c.a, c.b); !errors.Is(err, c.wantErr) {
+			t.Errorf("%s: validateVectors() = %v, want %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+// TestFloat32MatchesFloat64 checks that the float32 Manhattan, Euclidean,
+// SquaredEuclidean, Chebyshev, BrayCurtis and Canberra variants agree with
+// their float64 counterparts on the same input, within float32 precision. This is the parity test that would have caught
+// CanberraDistance32's mismatched denominator.
+func TestFloat32MatchesFloat64(t *testing.T) {
+	a, b := randomVectors(9, 7)
+	// Canberra's relative-difference form divides by |a_i|+|b_i|, which
+	// must be exercised with components of opposite sign: that is exactly
+	// where |a_i+b_i| (the BrayCurtis denominator) and |a_i|+|b_i| (the
+	// Canberra denominator) diverge.
+	aCanberra := []float64{3, 1, -1}
+	bCanberra := []float64{-1, 1, 1}
+	a32, b32 := toFloat32Vector(a), toFloat32Vector(b)
+	aCanberra32, bCanberra32 := toFloat32Vector(aCanberra), toFloat32Vector(bCanberra)
+
+	if want, err := CanberraDistance(aCanberra, bCanberra); err != nil || math.Abs(want-2) > 1e-9 {
+		t.Fatalf("sanity check: CanberraDistance({3,1,-1},{-1,1,1}) = %v, %v, want 2, nil", want, err)
+	}
+
+	cases := []struct {
+		name         string
+		want64       func() (float64, error)
+		got32        func() (float32, error)
+		relTolerance float64
+	}{
+		{"Manhattan", func() (float64, error) { return ManhattanDistance(a, b) }, func() (float32, error) { return ManhattanDistance32(a32, b32) }, 1e-3},
+		{"Euclidean", func() (float64, error) { return EuclideanDistance(a, b) }, func() (float32, error) { return EuclideanDistance32(a32, b32) }, 1e-3},
+		{"SquaredEuclidean", func() (float64, error) { return SquaredEuclideanDistance(a, b) }, func() (float32, error) { return SquaredEuclideanDistance32(a32, b32) }, 1e-3},
+		{"Chebyshev", func() (float64, error) { return ChebyshevDistance(a, b) }, func() (float32, error) { return ChebyshevDistance32(a32, b32) }, 1e-3},
+		{"BrayCurtis", func() (float64, error) { return BrayCurtisDistance(a, b) }, func() (float32, error) { return BrayCurtisDistance32(a32, b32) }, 1e-3},
+		{"Canberra", func() (float64, error) { return CanberraDistance(aCanberra, bCanberra) }, func() (float32, error) { return CanberraDistance32(aCanberra32, bCanberra32) }, 1e-3},
+	}
+	for _, c := range cases {
+		want, err := c.want64()
+		if err != nil {
+			t.Fatalf("%s: float64 variant returned error: %v", c.name, err)
+		}
+		got, err := c.got32()
+		if err != nil {
+			t.Fatalf("%s: float32 variant returned error: %v", c.name, err)
+		}
+		if math.Abs(float64(got)-want) > c.relTolerance*(math.Abs(want)+1) {
+			t.Errorf("%s32 = %v, want ~%v", c.name, got, want)
+		}
+	}
+}
+
+// TestSquareformRoundTrip checks that SquareformToCondensed inverts
+// Squareform for an arbitrary set of pairwise distances.
+func TestSquareformRoundTrip(t *testing.T) {
+	condensed := []float64{1, 2, 3, 4, 5, 6}
+	square, err := Squareform(condensed)
+	if err != nil {
+		t.Fatalf("Squareform() error: %v", err)
+	}
+	got, err := SquareformToCondensed(square)
+	if err != nil {
+		t.Fatalf("SquareformToCondensed() error: %v", err)
+	}
+	if len(got) != len(condensed) {
+		t.Fatalf("SquareformToCondensed() length = %d, want %d", len(got), len(condensed))
+	}
+	for i := range condensed {
+		if got[i] != condensed[i] {
+			t.Errorf("SquareformToCondensed()[%d] = %v, want %v", i, got[i], condensed[i])
+		}
+	}
+}
+
+// TestSquareformToCondensedRejectsAsymmetric checks that a non-symmetric
+// matrix is rejected rather than silently misread.
+func TestSquareformToCondensedRejectsAsymmetric(t *testing.T) {
+	matrix := [][]float64{
+		{0, 1, 2},
+		{1, 0, 3},
+		{2, 99, 0},
+	}
+	if _, err := SquareformToCondensed(matrix); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("SquareformToCondensed(asymmetric) error = %v, want ErrInvalidParameter", err)
+	}
+}
+
+// TestMahalanobisDistanceMatchesEuclideanUnderIdentity checks that with
+// the identity matrix as invCov, Mahalanobis reduces to plain Euclidean.
+func TestMahalanobisDistanceMatchesEuclideanUnderIdentity(t *testing.T) {
+	a := []float64{1, 2, 3}
+	b := []float64{4, 0, -1}
+	identity := [][]float64{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}
+	want, err := EuclideanDistance(a, b)
+	if err != nil {
+		t.Fatalf("EuclideanDistance() error: %v", err)
+	}
+	got, err := MahalanobisDistance(a, b, identity)
+	if err != nil {
+		t.Fatalf("MahalanobisDistance() error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("MahalanobisDistance() with identity invCov = %v, want %v", got, want)
+	}
+}
+
+// TestFastSquaredEuclideanPdistRejectsRaggedInput checks that Pdist
+// returns ErrSizeMismatch rather than panicking on ragged rows when it
+// takes the fast squared-Euclidean path.
+func TestFastSquaredEuclideanPdistRejectsRaggedInput(t *testing.T) {
+	X := [][]float64{{1, 2}, {3, 4, 5}}
+	_, err := Pdist(X, squaredEuclideanDistance{})
+	if !errors.Is(err, ErrSizeMismatch) {
+		t.Errorf("Pdist(ragged) error = %v, want ErrSizeMismatch", err)
+	}
+}
@@ -0,0 +1,183 @@
+package kmeans
+
+import "math"
+
+// Unit is a unit of length that earth-distance functions can report in.
+type Unit int
+
+const (
+	Meters Unit = iota
+	Kilometers
+	Miles
+	Feet
+	NauticalMiles
+)
+
+// earthRadiusMeters is the mean radius of the Earth (the IUGG value), used
+// as the default sphere radius for HaversineDistance. EarthDistance
+// predates this constant and keeps its own equatorial radius so its
+// output doesn't change for existing callers; the two great-circle
+// functions will disagree by ~0.1% as a result.
+const earthRadiusMeters = 6371008.8
+
+// toUnit converts a distance in meters to the given Unit.
+func toUnit(meters float64, unit Unit) (float64, error) {
+	switch unit {
+	case Meters:
+		return meters, nil
+	case Kilometers:
+		return meters / 1000, nil
+	case Miles:
+		return meters / 1609.344, nil
+	case Feet:
+		return meters / 0.3048, nil
+	case NauticalMiles:
+		return meters / 1852, nil
+	default:
+		return 0, ErrInvalidParameter
+	}
+}
+
+// HaversineDistance computes the great-circle distance between two
+// lng/lat points using the haversine formula, which is numerically stable
+// for points close together (unlike the spherical law of cosines used by
+// EarthDistance). Points are ordered [longitude, latitude], matching
+// EarthDistance; see LngLatDistance/LatLngDistance to be explicit about
+// input order.
+func HaversineDistance(firstVector, secondVector []float64, unit Unit) (float64, error) {
+	return HaversineDistanceWithRadius(firstVector, secondVector, unit, earthRadiusMeters)
+}
+
+// HaversineDistanceWithRadius is HaversineDistance parameterized on the
+// sphere radius, in meters, for callers who need a non-default Earth
+// radius (e.g. a different reference ellipsoid's mean radius, or a
+// different planet entirely).
+func HaversineDistanceWithRadius(firstVector, secondVector []float64, unit Unit, radiusMeters float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if len(firstVector) != 2 {
+		return 0, ErrInvalidParameter
+	}
+	if err := validateLngLat(firstVector); err != nil {
+		return 0, err
+	}
+	if err := validateLngLat(secondVector); err != nil {
+		return 0, err
+	}
+
+	lat1 := toRadians(firstVector[1])
+	lat2 := toRadians(secondVector[1])
+	dLat := toRadians(secondVector[1] - firstVector[1])
+	dLng := toRadians(secondVector[0] - firstVector[0])
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return toUnit(radiusMeters*c, unit)
+}
+
+// WGS-84 ellipsoid parameters used by VincentyDistance.
+const (
+	wgs84SemiMajorAxis    = 6378137.0
+	wgs84Flattening       = 1 / 298.257223563
+	wgs84SemiMinorAxis    = wgs84SemiMajorAxis * (1 - wgs84Flattening)
+	vincentyMaxIterations = 200
+	vincentyConvergence   = 1e-12
+)
+
+// VincentyDistance computes the geodesic distance between two lng/lat
+// points on the WGS-84 ellipsoid using Vincenty's inverse formula, which
+// is accurate to within a millimeter (vs. the spherical approximation of
+// HaversineDistance/EarthDistance). Points are ordered [longitude,
+// latitude]. Returns ErrInvalidParameter if the iteration fails to
+// converge, which can happen for near-antipodal points.
+func VincentyDistance(firstVector, secondVector []float64, unit Unit) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if len(firstVector) != 2 {
+		return 0, ErrInvalidParameter
+	}
+	if err := validateLngLat(firstVector); err != nil {
+		return 0, err
+	}
+	if err := validateLngLat(secondVector); err != nil {
+		return 0, err
+	}
+
+	a, f, b := wgs84SemiMajorAxis, wgs84Flattening, wgs84SemiMinorAxis
+
+	L := toRadians(secondVector[0] - firstVector[0])
+	U1 := math.Atan((1 - f) * math.Tan(toRadians(firstVector[1])))
+	U2 := math.Atan((1 - f) * math.Tan(toRadians(secondVector[1])))
+	sinU1, cosU1 := math.Sin(U1), math.Cos(U1)
+	sinU2, cosU2 := math.Sin(U2), math.Cos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+
+	converged := false
+	for i := 0; i < vincentyMaxIterations; i++ {
+		sinLambda, cosLambda := math.Sin(lambda), math.Cos(lambda)
+		sinSigma = math.Sqrt(math.Pow(cosU2*sinLambda, 2) +
+			math.Pow(cosU1*sinU2-sinU1*cosU2*cosLambda, 2))
+		if sinSigma == 0 {
+			return 0, nil // coincident points
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		} else {
+			cos2SigmaM = 0 // equatorial line
+		}
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		lambdaPrev := lambda
+		lambda = L + (1-C)*f*sinAlpha*
+			(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-lambdaPrev) < vincentyConvergence {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, ErrInvalidParameter
+	}
+
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return toUnit(b*A*(sigma-deltaSigma), unit)
+}
+
+// LngLatDistance is EarthDistance under an explicit name: firstVector and
+// secondVector are ordered [longitude, latitude].
+func LngLatDistance(firstVector, secondVector []float64) (float64, error) {
+	return EarthDistance(firstVector, secondVector)
+}
+
+// LatLngDistance is EarthDistance for points ordered [latitude, longitude]
+// rather than EarthDistance's native [longitude, latitude].
+func LatLngDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if len(firstVector) != 2 {
+		return 0, ErrInvalidParameter
+	}
+	return EarthDistance(
+		[]float64{firstVector[1], firstVector[0]},
+		[]float64{secondVector[1], secondVector[0]},
+	)
+}
+
+func toRadians(d float64) float64 {
+	return d * math.Pi / 180.0
+}
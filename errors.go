@@ -0,0 +1,34 @@
+package kmeans
+
+import "errors"
+
+// Sentinel errors returned by the distance functions and their shared
+// validator. Callers should use errors.Is to check for these, since some
+// functions wrap them with additional context.
+var (
+	// ErrEmptyInput is returned when one or both input vectors are nil or
+	// have zero length.
+	ErrEmptyInput = errors.New("kmeans: empty input vector")
+
+	// ErrSizeMismatch is returned when input vectors that are expected to
+	// have the same length do not.
+	ErrSizeMismatch = errors.New("kmeans: input vectors have mismatched lengths")
+
+	// ErrInvalidParameter is returned when a function-specific parameter
+	// (e.g. p in MinkowskiDistance, or a weight) falls outside its valid
+	// range.
+	ErrInvalidParameter = errors.New("kmeans: invalid parameter")
+)
+
+// validateVectors checks that firstVector and secondVector are non-empty
+// and of equal length. It is shared by every float64 two-vector distance
+// function in this package; the float32 variants use validateVectors32.
+func validateVectors(firstVector, secondVector []float64) error {
+	if len(firstVector) == 0 || len(secondVector) == 0 {
+		return ErrEmptyInput
+	}
+	if len(firstVector) != len(secondVector) {
+		return ErrSizeMismatch
+	}
+	return nil
+}
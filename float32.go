@@ -0,0 +1,137 @@
+package kmeans
+
+import (
+	"math"
+
+	"wisblue/kmeans/vek"
+)
+
+// Float32 variants of the distance functions above. They exist for
+// memory-bound workloads over millions of vectors, where halving the
+// vector width (float64 -> float32) matters more than the lost precision.
+// Validation mirrors the float64 versions; SquaredEuclideanDistance32,
+// ManhattanDistance32 and ChebyshevDistance32 delegate their hot loop to
+// the vek subpackage, which dispatches to an AVX2 kernel on amd64 when
+// the CPU supports it and otherwise runs the pure-Go fallback.
+
+func validateVectors32(firstVector, secondVector []float32) error {
+	if len(firstVector) == 0 || len(secondVector) == 0 {
+		return ErrEmptyInput
+	}
+	if len(firstVector) != len(secondVector) {
+		return ErrSizeMismatch
+	}
+	return nil
+}
+
+// ManhattanDistance32 is the float32 variant of ManhattanDistance.
+func ManhattanDistance32(firstVector, secondVector []float32) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	return vek.Manhattan32(firstVector, secondVector), nil
+}
+
+// EuclideanDistance32 is the float32 variant of EuclideanDistance.
+func EuclideanDistance32(firstVector, secondVector []float32) (float32, error) {
+	distance, err := SquaredEuclideanDistance32(firstVector, secondVector)
+	if err != nil {
+		return 0, err
+	}
+	return float32(math.Sqrt(float64(distance))), nil
+}
+
+// SquaredEuclideanDistance32 is the float32 variant of
+// SquaredEuclideanDistance.
+func SquaredEuclideanDistance32(firstVector, secondVector []float32) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	return vek.SquaredEuclidean32(firstVector, secondVector), nil
+}
+
+// MinkowskiDistance32 is the float32 variant of MinkowskiDistance.
+func MinkowskiDistance32(firstVector, secondVector []float32, p float64) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if p < 1 {
+		return 0, ErrInvalidParameter
+	}
+	distance := 0.
+	for ii := range firstVector {
+		distance += math.Pow(math.Abs(float64(firstVector[ii]-secondVector[ii])), p)
+	}
+	return float32(math.Pow(distance, 1/p)), nil
+}
+
+// WeightedMinkowskiDistance32 is the float32 variant of
+// WeightedMinkowskiDistance.
+func WeightedMinkowskiDistance32(firstVector, secondVector, weightVector []float32, p float64) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	if p < 1 {
+		return 0, ErrInvalidParameter
+	}
+	if len(weightVector) != len(firstVector) {
+		return 0, ErrSizeMismatch
+	}
+	for _, w := range weightVector {
+		if w < 0 {
+			return 0, ErrInvalidParameter
+		}
+	}
+	distance := 0.
+	for ii := range firstVector {
+		distance += float64(weightVector[ii]) * math.Pow(math.Abs(float64(firstVector[ii]-secondVector[ii])), p)
+	}
+	return float32(math.Pow(distance, 1/p)), nil
+}
+
+// ChebyshevDistance32 is the float32 variant of ChebyshevDistance.
+func ChebyshevDistance32(firstVector, secondVector []float32) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	return vek.Chebyshev32(firstVector, secondVector), nil
+}
+
+// HammingDistance32 is the float32 variant of HammingDistance.
+func HammingDistance32(firstVector, secondVector []float32) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	distance := float32(0)
+	for ii := range firstVector {
+		if firstVector[ii] != secondVector[ii] {
+			distance++
+		}
+	}
+	return distance, nil
+}
+
+// BrayCurtisDistance32 is the float32 variant of BrayCurtisDistance.
+func BrayCurtisDistance32(firstVector, secondVector []float32) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	var numerator, denominator float32
+	for ii := range firstVector {
+		numerator += float32(math.Abs(float64(firstVector[ii] - secondVector[ii])))
+		denominator += float32(math.Abs(float64(firstVector[ii] + secondVector[ii])))
+	}
+	return numerator / denominator, nil
+}
+
+// CanberraDistance32 is the float32 variant of CanberraDistance.
+func CanberraDistance32(firstVector, secondVector []float32) (float32, error) {
+	if err := validateVectors32(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	var distance float32
+	for ii := range firstVector {
+		distance += float32(math.Abs(float64(firstVector[ii]-secondVector[ii])) / (math.Abs(float64(firstVector[ii])) + math.Abs(float64(secondVector[ii]))))
+	}
+	return distance, nil
+}
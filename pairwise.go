@@ -0,0 +1,232 @@
+package kmeans
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Pdist computes the condensed pairwise distance vector for every pair of
+// rows in X, using metric d. The result follows scipy's condensed-matrix
+// convention: for i<j, the distance between row i and row j is stored at
+// index n*i - i*(i+1)/2 + (j - i - 1), giving a vector of length
+// n*(n-1)/2 rather than the full redundant n*n matrix.
+func Pdist(X [][]float64, d Distance) ([]float64, error) {
+	n := len(X)
+	if n < 2 {
+		return nil, ErrEmptyInput
+	}
+
+	out := make([]float64, n*(n-1)/2)
+
+	if _, ok := d.(squaredEuclideanDistance); ok {
+		rowLen := len(X[0])
+		if rowLen == 0 {
+			return nil, ErrEmptyInput
+		}
+		for _, row := range X {
+			if len(row) != rowLen {
+				return nil, ErrSizeMismatch
+			}
+		}
+		fastSquaredEuclideanPdist(X, out)
+		return out, nil
+	}
+
+	type pair struct {
+		i, j, idx int
+	}
+	pairs := make([]pair, 0, len(out))
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			pairs = append(pairs, pair{i, j, condensedIndex(n, i, j)})
+		}
+	}
+
+	errs := make([]error, len(pairs))
+	runParallel(len(pairs), func(k int) {
+		p := pairs[k]
+		v, err := d.Compute(X[p.i], X[p.j])
+		if err != nil {
+			errs[k] = err
+			return
+		}
+		out[p.idx] = v
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Cdist computes the full m×n matrix of distances between every row of X
+// (m rows) and every row of Y (n rows), using metric d.
+func Cdist(X, Y [][]float64, d Distance) ([][]float64, error) {
+	m, n := len(X), len(Y)
+	if m == 0 || n == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	out := make([][]float64, m)
+	for i := range out {
+		out[i] = make([]float64, n)
+	}
+
+	errs := make([]error, m)
+	runParallel(m, func(i int) {
+		for j := 0; j < n; j++ {
+			v, err := d.Compute(X[i], Y[j])
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			out[i][j] = v
+		}
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Squareform converts a condensed pairwise distance vector (as returned by
+// Pdist) to the redundant, symmetric n×n matrix form. Use SquareformToCondensed
+// for the reverse conversion.
+func Squareform(distances []float64) ([][]float64, error) {
+	n := condensedSizeToN(len(distances))
+	if n < 0 {
+		return nil, fmt.Errorf("%w: condensed vector length %d is not a valid n*(n-1)/2", ErrInvalidParameter, len(distances))
+	}
+
+	out := make([][]float64, n)
+	for i := range out {
+		out[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			v := distances[condensedIndex(n, i, j)]
+			out[i][j] = v
+			out[j][i] = v
+		}
+	}
+	return out, nil
+}
+
+// SquareformToCondensed converts a redundant, symmetric n×n distance matrix
+// with a zero diagonal back to the condensed vector form used by Pdist and
+// Squareform. It is the inverse of Squareform.
+func SquareformToCondensed(matrix [][]float64) ([]float64, error) {
+	n := len(matrix)
+	if n == 0 {
+		return nil, ErrEmptyInput
+	}
+	for i, row := range matrix {
+		if len(row) != n {
+			return nil, fmt.Errorf("%w: matrix is not square (row %d has length %d, want %d)", ErrInvalidParameter, i, len(row), n)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if matrix[i][i] != 0 {
+			return nil, fmt.Errorf("%w: matrix diagonal must be zero (row %d)", ErrInvalidParameter, i)
+		}
+		for j := i + 1; j < n; j++ {
+			if matrix[i][j] != matrix[j][i] {
+				return nil, fmt.Errorf("%w: matrix is not symmetric (%d,%d) != (%d,%d)", ErrInvalidParameter, i, j, j, i)
+			}
+		}
+	}
+
+	out := make([]float64, n*(n-1)/2)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			out[condensedIndex(n, i, j)] = matrix[i][j]
+		}
+	}
+	return out, nil
+}
+
+// condensedIndex maps row/column (i, j), i<j, of an n×n distance matrix to
+// its position in the condensed vector, matching scipy's convention.
+func condensedIndex(n, i, j int) int {
+	return n*i - i*(i+1)/2 + (j - i - 1)
+}
+
+// condensedSizeToN recovers n from the length of a condensed vector of
+// size n*(n-1)/2, returning -1 if the length does not correspond to any n.
+func condensedSizeToN(size int) int {
+	// n*(n-1)/2 = size  =>  n = (1 + sqrt(1+8*size)) / 2
+	n := int((1 + math.Sqrt(1+8*float64(size))) / 2)
+	for _, candidate := range []int{n - 1, n, n + 1} {
+		if candidate > 0 && candidate*(candidate-1)/2 == size {
+			return candidate
+		}
+	}
+	return -1
+}
+
+// fastSquaredEuclideanPdist fills out with the condensed pairwise squared
+// Euclidean distances of X, expanding ||a-b||^2 = ||a||^2 + ||b||^2 -
+// 2*a.b so that each row's norm is computed once and every pair is then a
+// single dot product. Callers must ensure every row of X has the same
+// non-zero length; Pdist validates this before calling in.
+func fastSquaredEuclideanPdist(X [][]float64, out []float64) {
+	n := len(X)
+	norms := make([]float64, n)
+	for i, row := range X {
+		var sum float64
+		for _, v := range row {
+			sum += v * v
+		}
+		norms[i] = sum
+	}
+
+	runParallel(n, func(i int) {
+		row := X[i]
+		for j := i + 1; j < n; j++ {
+			var dot float64
+			other := X[j]
+			for k := range row {
+				dot += row[k] * other[k]
+			}
+			d := norms[i] + norms[j] - 2*dot
+			if d < 0 {
+				d = 0
+			}
+			out[condensedIndex(n, i, j)] = d
+		}
+	})
+}
+
+// runParallel runs fn(i) for i in [0, count) across a bounded worker pool
+// sized to GOMAXPROCS, blocking until every call has completed.
+func runParallel(count int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > count {
+		workers = count
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	jobs := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,178 @@
+package kmeans
+
+import "fmt"
+
+// Distance is implemented by every metric in this package, letting callers
+// select a metric by name (see Register/ByName) instead of wiring up a raw
+// function pointer. This package has no Kmeans(...) driver of its own yet,
+// so ByName's result is meant for a caller-supplied clustering loop rather
+// than any entry point defined here.
+type Distance interface {
+	Compute(firstVector, secondVector []float64) (float64, error)
+	Name() string
+}
+
+// Factory builds a Distance from a set of named parameters, e.g. {"p": 3.0}
+// for Minkowski. The "earth" factory takes no parameters: it has no unit
+// option and always reports meters. Implementations should ignore
+// unrecognised keys and return ErrInvalidParameter for out-of-range ones.
+type Factory func(params map[string]any) (Distance, error)
+
+var registry = map[string]Factory{}
+
+// Register adds a named Distance factory to the package-level registry, so
+// it becomes selectable via ByName. Register is typically called from an
+// init function; registering the same name twice overwrites the previous
+// factory.
+func Register(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// ByName looks up a registered Distance factory and builds a Distance from
+// params. It returns ErrInvalidParameter wrapped with the unknown name if
+// no factory is registered under name.
+func ByName(name string, params map[string]any) (Distance, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: no distance registered under %q", ErrInvalidParameter, name)
+	}
+	return factory(params)
+}
+
+func init() {
+	Register("manhattan", func(map[string]any) (Distance, error) { return manhattanDistance{}, nil })
+	Register("euclidean", func(map[string]any) (Distance, error) { return euclideanDistance{}, nil })
+	Register("squared_euclidean", func(map[string]any) (Distance, error) { return squaredEuclideanDistance{}, nil })
+	Register("chebyshev", func(map[string]any) (Distance, error) { return chebyshevDistance{}, nil })
+	Register("hamming", func(map[string]any) (Distance, error) { return hammingDistance{}, nil })
+	Register("bray_curtis", func(map[string]any) (Distance, error) { return brayCurtisDistance{}, nil })
+	Register("canberra", func(map[string]any) (Distance, error) { return canberraDistance{}, nil })
+	Register("cosine", func(map[string]any) (Distance, error) { return cosineDistance{}, nil })
+	Register("angular", func(map[string]any) (Distance, error) { return angularDistance{}, nil })
+	Register("correlation", func(map[string]any) (Distance, error) { return correlationDistance{}, nil })
+
+	Register("earth", func(params map[string]any) (Distance, error) {
+		return earthDistance{}, nil
+	})
+
+	Register("minkowski", func(params map[string]any) (Distance, error) {
+		p, err := floatParam(params, "p", 2)
+		if err != nil {
+			return nil, err
+		}
+		if p < 1 {
+			return nil, ErrInvalidParameter
+		}
+		return minkowskiDistance{p: p}, nil
+	})
+
+	Register("weighted_minkowski", func(params map[string]any) (Distance, error) {
+		p, err := floatParam(params, "p", 2)
+		if err != nil {
+			return nil, err
+		}
+		weights, ok := params["weights"].([]float64)
+		if !ok {
+			return nil, fmt.Errorf("%w: weighted_minkowski requires a []float64 \"weights\" parameter", ErrInvalidParameter)
+		}
+		if p < 1 {
+			return nil, ErrInvalidParameter
+		}
+		for _, w := range weights {
+			if w < 0 {
+				return nil, ErrInvalidParameter
+			}
+		}
+		return weightedMinkowskiDistance{p: p, weights: weights}, nil
+	})
+}
+
+// floatParam extracts a float64 parameter named key from params, falling
+// back to def if the key is absent.
+func floatParam(params map[string]any, key string, def float64) (float64, error) {
+	raw, ok := params[key]
+	if !ok {
+		return def, nil
+	}
+	value, ok := raw.(float64)
+	if !ok {
+		return 0, fmt.Errorf("%w: parameter %q must be a float64", ErrInvalidParameter, key)
+	}
+	return value, nil
+}
+
+type manhattanDistance struct{}
+
+func (manhattanDistance) Compute(a, b []float64) (float64, error) { return ManhattanDistance(a, b) }
+func (manhattanDistance) Name() string                            { return "manhattan" }
+
+type euclideanDistance struct{}
+
+func (euclideanDistance) Compute(a, b []float64) (float64, error) { return EuclideanDistance(a, b) }
+func (euclideanDistance) Name() string                            { return "euclidean" }
+
+type squaredEuclideanDistance struct{}
+
+func (squaredEuclideanDistance) Compute(a, b []float64) (float64, error) {
+	return SquaredEuclideanDistance(a, b)
+}
+func (squaredEuclideanDistance) Name() string { return "squared_euclidean" }
+
+type chebyshevDistance struct{}
+
+func (chebyshevDistance) Compute(a, b []float64) (float64, error) { return ChebyshevDistance(a, b) }
+func (chebyshevDistance) Name() string                            { return "chebyshev" }
+
+type hammingDistance struct{}
+
+func (hammingDistance) Compute(a, b []float64) (float64, error) { return HammingDistance(a, b) }
+func (hammingDistance) Name() string                            { return "hamming" }
+
+type brayCurtisDistance struct{}
+
+func (brayCurtisDistance) Compute(a, b []float64) (float64, error) { return BrayCurtisDistance(a, b) }
+func (brayCurtisDistance) Name() string                            { return "bray_curtis" }
+
+type canberraDistance struct{}
+
+func (canberraDistance) Compute(a, b []float64) (float64, error) { return CanberraDistance(a, b) }
+func (canberraDistance) Name() string                            { return "canberra" }
+
+type earthDistance struct{}
+
+func (earthDistance) Compute(a, b []float64) (float64, error) { return EarthDistance(a, b) }
+func (earthDistance) Name() string                            { return "earth" }
+
+type cosineDistance struct{}
+
+func (cosineDistance) Compute(a, b []float64) (float64, error) { return CosineDistance(a, b) }
+func (cosineDistance) Name() string                            { return "cosine" }
+
+type angularDistance struct{}
+
+func (angularDistance) Compute(a, b []float64) (float64, error) { return AngularDistance(a, b) }
+func (angularDistance) Name() string                            { return "angular" }
+
+type correlationDistance struct{}
+
+func (correlationDistance) Compute(a, b []float64) (float64, error) {
+	return CorrelationDistance(a, b)
+}
+func (correlationDistance) Name() string { return "correlation" }
+
+type minkowskiDistance struct{ p float64 }
+
+func (d minkowskiDistance) Compute(a, b []float64) (float64, error) {
+	return MinkowskiDistance(a, b, d.p)
+}
+func (minkowskiDistance) Name() string { return "minkowski" }
+
+type weightedMinkowskiDistance struct {
+	p       float64
+	weights []float64
+}
+
+func (d weightedMinkowskiDistance) Compute(a, b []float64) (float64, error) {
+	return WeightedMinkowskiDistance(a, b, d.weights, d.p)
+}
+func (weightedMinkowskiDistance) Name() string { return "weighted_minkowski" }
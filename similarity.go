@@ -0,0 +1,213 @@
+package kmeans
+
+import "math"
+
+// JaccardDistance treats firstVector and secondVector as binary/set
+// indicator vectors (non-zero entries are set members) and returns
+// 1 - |A∩B|/|A∪B|.
+func JaccardDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	var intersection, union int
+	for ii := range firstVector {
+		inA := firstVector[ii] != 0
+		inB := secondVector[ii] != 0
+		if inA && inB {
+			intersection++
+		}
+		if inA || inB {
+			union++
+		}
+	}
+	if union == 0 {
+		return 0, ErrInvalidParameter
+	}
+	return 1 - float64(intersection)/float64(union), nil
+}
+
+// TanimotoDistance is the Jaccard distance generalized to real-valued
+// vectors: 1 - (a·b)/(||a||² + ||b||² - a·b).
+func TanimotoDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	var dot, normFirst, normSecond float64
+	for ii := range firstVector {
+		dot += firstVector[ii] * secondVector[ii]
+		normFirst += firstVector[ii] * firstVector[ii]
+		normSecond += secondVector[ii] * secondVector[ii]
+	}
+	denominator := normFirst + normSecond - dot
+	if denominator == 0 {
+		return 0, ErrInvalidParameter
+	}
+	return 1 - dot/denominator, nil
+}
+
+// SorensenDiceDistance treats firstVector and secondVector as binary/set
+// indicator vectors and returns 1 - 2|A∩B|/(|A|+|B|).
+func SorensenDiceDistance(firstVector, secondVector []float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	var intersection, sizeA, sizeB int
+	for ii := range firstVector {
+		inA := firstVector[ii] != 0
+		inB := secondVector[ii] != 0
+		if inA {
+			sizeA++
+		}
+		if inB {
+			sizeB++
+		}
+		if inA && inB {
+			intersection++
+		}
+	}
+	if sizeA+sizeB == 0 {
+		return 0, ErrInvalidParameter
+	}
+	return 1 - 2*float64(intersection)/float64(sizeA+sizeB), nil
+}
+
+// MahalanobisDistance computes sqrt((a-b)^T * invCov * (a-b)), where invCov
+// is the inverse of the sample covariance matrix (see CovarianceInverse).
+// Unlike the Lp-family distances, Mahalanobis accounts for the spread and
+// correlation of each dimension, making it cluster-shape-aware rather than
+// assuming isotropic clusters.
+func MahalanobisDistance(firstVector, secondVector []float64, invCov [][]float64) (float64, error) {
+	if err := validateVectors(firstVector, secondVector); err != nil {
+		return 0, err
+	}
+	n := len(firstVector)
+	if len(invCov) != n {
+		return 0, ErrSizeMismatch
+	}
+	for _, row := range invCov {
+		if len(row) != n {
+			return 0, ErrSizeMismatch
+		}
+	}
+
+	diff := make([]float64, n)
+	for ii := range firstVector {
+		diff[ii] = firstVector[ii] - secondVector[ii]
+	}
+
+	var quadraticForm float64
+	for ii := 0; ii < n; ii++ {
+		var rowSum float64
+		for jj := 0; jj < n; jj++ {
+			rowSum += invCov[ii][jj] * diff[jj]
+		}
+		quadraticForm += diff[ii] * rowSum
+	}
+	if quadraticForm < 0 {
+		quadraticForm = 0
+	}
+	return math.Sqrt(quadraticForm), nil
+}
+
+// CovarianceInverse computes the sample covariance matrix of the rows of X
+// (each row an observation, each column a dimension) and returns its
+// inverse, ready to pass to MahalanobisDistance. Returns ErrEmptyInput if X
+// has fewer than 2 rows (sample covariance is undefined for a single
+// observation) and ErrInvalidParameter if the covariance matrix is
+// singular.
+func CovarianceInverse(X [][]float64) ([][]float64, error) {
+	n := len(X)
+	if n < 2 {
+		return nil, ErrEmptyInput
+	}
+	d := len(X[0])
+	for _, row := range X {
+		if len(row) != d {
+			return nil, ErrSizeMismatch
+		}
+	}
+	if d == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	means := make([]float64, d)
+	for _, row := range X {
+		for j, v := range row {
+			means[j] += v
+		}
+	}
+	for j := range means {
+		means[j] /= float64(n)
+	}
+
+	cov := make([][]float64, d)
+	for i := range cov {
+		cov[i] = make([]float64, d)
+	}
+	for _, row := range X {
+		for i := 0; i < d; i++ {
+			di := row[i] - means[i]
+			for j := 0; j < d; j++ {
+				cov[i][j] += di * (row[j] - means[j])
+			}
+		}
+	}
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			cov[i][j] /= float64(n - 1)
+		}
+	}
+
+	return invertMatrix(cov)
+}
+
+// invertMatrix inverts a square matrix via Gauss-Jordan elimination with
+// partial pivoting, returning ErrInvalidParameter if the matrix is
+// singular.
+func invertMatrix(matrix [][]float64) ([][]float64, error) {
+	n := len(matrix)
+
+	augmented := make([][]float64, n)
+	for i := range augmented {
+		augmented[i] = make([]float64, 2*n)
+		copy(augmented[i], matrix[i])
+		augmented[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivotRow := col
+		maxAbs := math.Abs(augmented[col][col])
+		for row := col + 1; row < n; row++ {
+			if abs := math.Abs(augmented[row][col]); abs > maxAbs {
+				maxAbs = abs
+				pivotRow = row
+			}
+		}
+		if maxAbs == 0 {
+			return nil, ErrInvalidParameter
+		}
+		augmented[col], augmented[pivotRow] = augmented[pivotRow], augmented[col]
+
+		pivot := augmented[col][col]
+		for j := 0; j < 2*n; j++ {
+			augmented[col][j] /= pivot
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col {
+				continue
+			}
+			factor := augmented[row][col]
+			for j := 0; j < 2*n; j++ {
+				augmented[row][j] -= factor * augmented[col][j]
+			}
+		}
+	}
+
+	inverse := make([][]float64, n)
+	for i := range inverse {
+		inverse[i] = make([]float64, n)
+		copy(inverse[i], augmented[i][n:])
+	}
+	return inverse, nil
+}
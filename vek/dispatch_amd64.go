@@ -0,0 +1,28 @@
+//go:build amd64
+
+package vek
+
+import "golang.org/x/sys/cpu"
+
+// useAVX2 reports whether the running CPU has the AVX2 and FMA extensions
+// that the assembly kernels declared in this file require. It is checked
+// once at package init via golang.org/x/sys/cpu rather than on every call.
+var useAVX2 = cpu.X86.HasAVX2 && cpu.X86.HasFMA
+
+//go:noescape
+func squaredEuclidean64AVX2(a, b []float64) float64
+
+//go:noescape
+func squaredEuclidean32AVX2(a, b []float32) float32
+
+//go:noescape
+func manhattan64AVX2(a, b []float64) float64
+
+//go:noescape
+func manhattan32AVX2(a, b []float32) float32
+
+//go:noescape
+func chebyshev64AVX2(a, b []float64) float64
+
+//go:noescape
+func chebyshev32AVX2(a, b []float32) float32
@@ -0,0 +1,15 @@
+//go:build !amd64
+
+package vek
+
+// useAVX2 is always false outside amd64: the assembly kernels in this
+// package are amd64-only, so every call below falls back to the pure-Go
+// loops in vek.go.
+var useAVX2 = false
+
+func squaredEuclidean64AVX2(a, b []float64) float64 { return squaredEuclidean64Generic(a, b) }
+func squaredEuclidean32AVX2(a, b []float32) float32 { return squaredEuclidean32Generic(a, b) }
+func manhattan64AVX2(a, b []float64) float64        { return manhattan64Generic(a, b) }
+func manhattan32AVX2(a, b []float32) float32        { return manhattan32Generic(a, b) }
+func chebyshev64AVX2(a, b []float64) float64        { return chebyshev64Generic(a, b) }
+func chebyshev32AVX2(a, b []float32) float32        { return chebyshev32Generic(a, b) }
@@ -0,0 +1,131 @@
+// Package vek provides the vectorized hot-loop kernels behind kmeans's
+// SquaredEuclidean, Manhattan, and Chebyshev distances for both float64 and
+// float32 slices.
+//
+// On amd64, each exported function dispatches to a hand-written AVX2
+// assembly kernel (squared_euclidean_amd64.s, manhattan_amd64.s,
+// chebyshev_amd64.s) when golang.org/x/sys/cpu reports the running CPU
+// supports AVX2 and FMA, and otherwise falls back to the pure-Go loop below. On
+// every other architecture (including arm64, where a NEON kernel has not
+// been written yet) only the pure-Go fallback is compiled.
+package vek
+
+// SquaredEuclidean64 returns the squared Euclidean distance between a and
+// b. Callers are expected to have validated len(a) == len(b) and that
+// neither slice is empty.
+func SquaredEuclidean64(a, b []float64) float64 {
+	if useAVX2 {
+		return squaredEuclidean64AVX2(a, b)
+	}
+	return squaredEuclidean64Generic(a, b)
+}
+
+// SquaredEuclidean32 is SquaredEuclidean64 for float32 slices, for
+// workloads over millions of vectors where halving the working set
+// outweighs the loss of precision.
+func SquaredEuclidean32(a, b []float32) float32 {
+	if useAVX2 {
+		return squaredEuclidean32AVX2(a, b)
+	}
+	return squaredEuclidean32Generic(a, b)
+}
+
+// Manhattan64 returns the sum of absolute differences between a and b.
+func Manhattan64(a, b []float64) float64 {
+	if useAVX2 {
+		return manhattan64AVX2(a, b)
+	}
+	return manhattan64Generic(a, b)
+}
+
+// Manhattan32 is Manhattan64 for float32 slices.
+func Manhattan32(a, b []float32) float32 {
+	if useAVX2 {
+		return manhattan32AVX2(a, b)
+	}
+	return manhattan32Generic(a, b)
+}
+
+// Chebyshev64 returns the maximum absolute difference between a and b.
+func Chebyshev64(a, b []float64) float64 {
+	if useAVX2 {
+		return chebyshev64AVX2(a, b)
+	}
+	return chebyshev64Generic(a, b)
+}
+
+// Chebyshev32 is Chebyshev64 for float32 slices.
+func Chebyshev32(a, b []float32) float32 {
+	if useAVX2 {
+		return chebyshev32AVX2(a, b)
+	}
+	return chebyshev32Generic(a, b)
+}
+
+func squaredEuclidean64Generic(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+func squaredEuclidean32Generic(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
+
+func manhattan64Generic(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		sum += abs64(a[i] - b[i])
+	}
+	return sum
+}
+
+func manhattan32Generic(a, b []float32) float32 {
+	var sum float32
+	for i := range a {
+		sum += abs32(a[i] - b[i])
+	}
+	return sum
+}
+
+func chebyshev64Generic(a, b []float64) float64 {
+	var max float64
+	for i := range a {
+		if d := abs64(a[i] - b[i]); d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+func chebyshev32Generic(a, b []float32) float32 {
+	var max float32
+	for i := range a {
+		if d := abs32(a[i] - b[i]); d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+func abs64(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
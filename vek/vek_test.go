@@ -0,0 +1,161 @@
+package vek
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randomVectors64(n int, seed int64) (a, b []float64) {
+	rng := rand.New(rand.NewSource(seed))
+	a = make([]float64, n)
+	b = make([]float64, n)
+	for i := range a {
+		a[i] = rng.Float64()*20 - 10
+		b[i] = rng.Float64()*20 - 10
+	}
+	return a, b
+}
+
+func toFloat32(v []float64) []float32 {
+	out := make([]float32, len(v))
+	for i, x := range v {
+		out[i] = float32(x)
+	}
+	return out
+}
+
+// lengths exercise the scalar-only path (n<4), the exact AVX2 block
+// widths this package unrolls to (4, 8, 16, 32), and non-multiples that
+// fall through every tail.
+var vekTestLengths = []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 15, 16, 17, 31, 32, 33, 63, 128, 129}
+
+func TestSquaredEuclideanMatchesNaive(t *testing.T) {
+	for _, n := range vekTestLengths {
+		a, b := randomVectors64(n, int64(n)+1)
+		var want float64
+		for i := range a {
+			d := a[i] - b[i]
+			want += d * d
+		}
+		if got := SquaredEuclidean64(a, b); math.Abs(got-want) > 1e-9 {
+			t.Errorf("SquaredEuclidean64(n=%d) = %v, want %v", n, got, want)
+		}
+		a32, b32 := toFloat32(a), toFloat32(b)
+		var want32 float32
+		for i := range a32 {
+			d := a32[i] - b32[i]
+			want32 += d * d
+		}
+		if got := SquaredEuclidean32(a32, b32); math.Abs(float64(got-want32)) > 1e-3*float64(want32+1) {
+			t.Errorf("SquaredEuclidean32(n=%d) = %v, want %v", n, got, want32)
+		}
+	}
+}
+
+func TestManhattanMatchesNaive(t *testing.T) {
+	for _, n := range vekTestLengths {
+		a, b := randomVectors64(n, int64(n)+2)
+		var want float64
+		for i := range a {
+			want += math.Abs(a[i] - b[i])
+		}
+		if got := Manhattan64(a, b); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Manhattan64(n=%d) = %v, want %v", n, got, want)
+		}
+		a32, b32 := toFloat32(a), toFloat32(b)
+		var want32 float32
+		for i := range a32 {
+			d := a32[i] - b32[i]
+			if d < 0 {
+				d = -d
+			}
+			want32 += d
+		}
+		if got := Manhattan32(a32, b32); math.Abs(float64(got-want32)) > 1e-3*float64(want32+1) {
+			t.Errorf("Manhattan32(n=%d) = %v, want %v", n, got, want32)
+		}
+	}
+}
+
+func TestChebyshevMatchesNaive(t *testing.T) {
+	for _, n := range vekTestLengths {
+		a, b := randomVectors64(n, int64(n)+3)
+		var want float64
+		for i := range a {
+			if d := math.Abs(a[i] - b[i]); d > want {
+				want = d
+			}
+		}
+		if got := Chebyshev64(a, b); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Chebyshev64(n=%d) = %v, want %v", n, got, want)
+		}
+		a32, b32 := toFloat32(a), toFloat32(b)
+		var want32 float32
+		for i := range a32 {
+			d := a32[i] - b32[i]
+			if d < 0 {
+				d = -d
+			}
+			if d > want32 {
+				want32 = d
+			}
+		}
+		if got := Chebyshev32(a32, b32); math.Abs(float64(got-want32)) > 1e-3*float64(want32+1) {
+			t.Errorf("Chebyshev32(n=%d) = %v, want %v", n, got, want32)
+		}
+	}
+}
+
+// benchmarkRatio runs fn twice (generic, then accelerated) with an
+// auto-calibrated b.N via testing.Benchmark and returns generic_ns/accel_ns.
+func benchmarkRatio(genericFn, accelFn func(b *testing.B)) float64 {
+	generic := testing.Benchmark(genericFn)
+	accel := testing.Benchmark(accelFn)
+	return float64(generic.NsPerOp()) / float64(accel.NsPerOp())
+}
+
+// TestAVX2SquaredEuclideanSpeedupGate is the benchmark gate for the
+// accelerated path: on an AVX2-capable amd64 host, SquaredEuclidean64
+// over 128-dim vectors (the k-means hot path this package targets) must
+// beat the pure-Go fallback by at least 3x. It's a hardware-dependent
+// gate, not a portability requirement, so it's skipped wherever AVX2
+// (or the assembly itself) isn't available. Timing noise on shared CI
+// hardware is handled by taking the best ratio across a few attempts
+// rather than failing on the first unlucky sample.
+func TestAVX2SquaredEuclideanSpeedupGate(t *testing.T) {
+	if !useAVX2 {
+		t.Skip("AVX2 not available on this host/arch; skipping the accelerated-vs-fallback benchmark gate")
+	}
+
+	const dim = 128
+	a, b := randomVectors64(dim, 42)
+
+	const minSpeedup = 3.0
+	const attempts = 5
+
+	var best float64
+	for i := 0; i < attempts; i++ {
+		ratio := benchmarkRatio(func(bench *testing.B) {
+			for i := 0; i < bench.N; i++ {
+				squaredEuclidean64Generic(a, b)
+			}
+		}, func(bench *testing.B) {
+			for i := 0; i < bench.N; i++ {
+				squaredEuclidean64AVX2(a, b)
+			}
+		})
+		if ratio > best {
+			best = ratio
+		}
+		if best >= minSpeedup {
+			break
+		}
+	}
+
+	if best < minSpeedup {
+		t.Errorf("AVX2 SquaredEuclidean64 speedup over %d attempts = %.2fx, want >= %.1fx", attempts, best, minSpeedup)
+	} else {
+		t.Logf("AVX2 SquaredEuclidean64 speedup = %.2fx", best)
+	}
+}